@@ -0,0 +1,275 @@
+package model_serializer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// FieldFilter decides whether a struct field should be included when
+// serializing, and if so, which filter (if any) should be applied to its own
+// nested fields. It lets callers pass a compiled filter instead of building
+// []interface{} selections by hand.
+//
+// Filter is called with the Go struct field name, exactly like the plain
+// string entries already accepted by Serialize. A nil subFilter means
+// "include the field fully, unfiltered"; a non-nil subFilter is applied
+// recursively when the field is itself a struct or a slice/array of structs.
+type FieldFilter interface {
+	Filter(fieldName string) (subFilter FieldFilter, ok bool)
+}
+
+// Mask is a FieldFilter that whitelists an explicit set of fields.
+type Mask map[string]FieldFilter
+
+// Filter implements FieldFilter.
+func (m Mask) Filter(fieldName string) (FieldFilter, bool) {
+	sub, ok := m[fieldName]
+	return sub, ok
+}
+
+// MaskInverse is a FieldFilter that includes every field except the ones
+// listed, handy for stripping a handful of fields (e.g. passwords) without
+// having to whitelist everything else. The same exclusion set is applied at
+// every nesting level, so a field like "Password" is stripped out of nested
+// structs and slices/arrays of structs too, not just the top level.
+type MaskInverse map[string]struct{}
+
+// Filter implements FieldFilter.
+func (m MaskInverse) Filter(fieldName string) (FieldFilter, bool) {
+	if _, excluded := m[fieldName]; excluded {
+		return nil, false
+	}
+	return m, true
+}
+
+// maskWithCallbacks lets NewMask preserve FieldSerializer entries nested
+// inside a map[string][]interface{} selection. A FieldSerializer does not
+// address a struct field, so it can't live inside the Mask tree itself; it's
+// carried alongside the Mask and applied by Serialize/SerializeFilter after
+// the nested field has been serialized.
+type maskWithCallbacks struct {
+	Mask
+	callbacks []FieldSerializer
+}
+
+// NewMask adapts the legacy Serialize field selection (plain strings, dotted
+// paths and map[string][]interface{} nesting) into a Mask, so both the
+// []interface{} API and FieldFilter are driven by the same walk. The
+// FieldSerializer entries are returned separately since they don't address a
+// struct field.
+func NewMask(fields []interface{}) (Mask, []FieldSerializer) {
+	mask := Mask{}
+	var callbacks []FieldSerializer
+	for _, f := range compileFields(fields) {
+		switch v := f.(type) {
+		case string:
+			mask[v] = nil
+		case FieldSerializer:
+			callbacks = append(callbacks, v)
+		case map[string][]interface{}:
+			for name, sub := range v {
+				subMask, subCallbacks := NewMask(sub)
+				if len(subCallbacks) > 0 {
+					mask[name] = maskWithCallbacks{subMask, subCallbacks}
+				} else {
+					mask[name] = subMask
+				}
+			}
+		}
+	}
+	return mask, callbacks
+}
+
+// ParseMask parses a compact selection syntax such as
+// "Id,Name,Owner{Id,Email},Products{Price}" into a Mask. Field names are
+// comma-separated, and "{...}" opens a nested selection for a struct, a
+// slice/array of structs, or a pointer to one, mirroring what
+// map[string][]interface{} does for the []interface{} API.
+func ParseMask(syntax string) (Mask, error) {
+	mask, rest, err := parseMask(syntax)
+	if err != nil {
+		return nil, err
+	}
+	if rest != "" {
+		return nil, fmt.Errorf("model_serializer: unexpected trailing input %q", rest)
+	}
+	return mask, nil
+}
+
+func parseMask(syntax string) (mask Mask, rest string, err error) {
+	mask = Mask{}
+	for syntax != "" {
+		name, after := splitMaskToken(syntax)
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, "", fmt.Errorf("model_serializer: empty field name in %q", syntax)
+		}
+
+		switch {
+		case strings.HasPrefix(after, "{"):
+			var sub Mask
+			sub, after, err = parseMask(after[1:])
+			if err != nil {
+				return nil, "", err
+			}
+			if !strings.HasPrefix(after, "}") {
+				return nil, "", fmt.Errorf("model_serializer: missing closing '}' for field %q", name)
+			}
+			after = after[1:]
+			mask[name] = sub
+		default:
+			mask[name] = nil
+		}
+
+		switch {
+		case strings.HasPrefix(after, ","):
+			syntax = after[1:]
+		default:
+			return mask, after, nil
+		}
+	}
+	return mask, "", nil
+}
+
+// splitMaskToken splits off the next field name up to the first "," "{" or
+// "}" delimiter, returning the name and the remaining, still-unparsed input.
+func splitMaskToken(syntax string) (name, rest string) {
+	idx := strings.IndexAny(syntax, ",{}")
+	if idx < 0 {
+		return syntax, ""
+	}
+	return syntax[:idx], syntax[idx:]
+}
+
+// SerializeFilter serializes model according to filter, walking every field
+// of the struct and asking filter.Filter whether to keep it. It is the
+// FieldFilter-driven counterpart of Serialize; Serialize itself is
+// implemented in terms of it via NewMask.
+func SerializeFilter(model interface{}, filter FieldFilter, opts ...Option) map[string]interface{} {
+	result := make(map[string]interface{})
+
+	val := reflect.ValueOf(model)
+	if val.IsNil() {
+		return result
+	}
+
+	options := buildOptions(opts)
+	elem := val.Elem()
+	info := getTypeInfo(elem.Type())
+
+	for _, field := range info.fields {
+		subFilter, ok := filter.Filter(field.name)
+		if !ok {
+			continue
+		}
+
+		serializedName := field.serializedName
+		if serializedName == "" {
+			if !options.FallbackToFieldName {
+				panic(fmt.Sprintf(`Field "%s" has no json tag`, field.name))
+			}
+			serializedName = field.name
+		}
+		fieldValue := elem.Field(field.index)
+
+		if serialized, handled := serializeCustomField(fieldValue, subFilter); handled {
+			result[serializedName] = serialized
+			continue
+		}
+
+		if field.kind == kindString {
+			result[serializedName] = fieldValue.Interface()
+			continue
+		}
+
+		if subFilter == nil {
+			result[serializedName] = fieldValue.Interface()
+			continue
+		}
+
+		// A slice/array or pointer that holds no struct has no fields to
+		// filter, so it is kept as it is.
+		if (field.kind == kindSlice || field.kind == kindPtr) &&
+			nestedElemType(fieldValue.Type()).Kind() != reflect.Struct {
+			result[serializedName] = fieldValue.Interface()
+			continue
+		}
+
+		switch field.kind {
+		case kindSlice:
+			serializeArrayByFilter(fieldValue, serializedName, subFilter, result, opts)
+		case kindPtr:
+			nested := fieldValue.Interface()
+			result[serializedName] = SerializeFilter(nested, subFilter, opts...)
+			applyMaskCallbacks(nested, subFilter, result[serializedName].(map[string]interface{}))
+		case kindNestedStruct:
+			nested := fieldValue.Addr().Interface()
+			result[serializedName] = SerializeFilter(nested, subFilter, opts...)
+			applyMaskCallbacks(nested, subFilter, result[serializedName].(map[string]interface{}))
+		case kindMap:
+			result[serializedName] = serializeMapByFilter(fieldValue, subFilter, opts)
+		default:
+			panic("Undefined type for serializer. Need to implement it")
+		}
+	}
+	return result
+}
+
+func serializeArrayByFilter(fieldValue reflect.Value, fieldName string,
+	filter FieldFilter, targetMap map[string]interface{}, opts []Option,
+) {
+	serializedContainer := make([]interface{}, fieldValue.Len())
+	for i := 0; i < fieldValue.Len(); i++ {
+		elemInterface := fieldValue.Index(i).Interface()
+		elemResult := SerializeFilter(elemInterface, filter, opts...)
+		applyMaskCallbacks(elemInterface, filter, elemResult)
+		serializedContainer[i] = elemResult
+	}
+	targetMap[fieldName] = serializedContainer
+}
+
+// serializeMapByFilter serializes a map field by recursively serializing
+// every value that is itself a struct or pointer to one, driven by the same
+// filter applied to each value; any other value kind passes through as-is.
+func serializeMapByFilter(fieldValue reflect.Value, filter FieldFilter, opts []Option) map[string]interface{} {
+	result := make(map[string]interface{}, fieldValue.Len())
+	for _, key := range fieldValue.MapKeys() {
+		value := fieldValue.MapIndex(key)
+		result[fmt.Sprint(key.Interface())] = serializeMapValueByFilter(value, filter, opts)
+	}
+	return result
+}
+
+func serializeMapValueByFilter(value reflect.Value, filter FieldFilter, opts []Option) interface{} {
+	if value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	if serialized, handled := serializeCustomField(value, filter); handled {
+		return serialized
+	}
+
+	if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Kind() == reflect.Struct {
+		return SerializeFilter(value.Interface(), filter, opts...)
+	}
+	if value.Kind() == reflect.Struct {
+		ptr := reflect.New(value.Type())
+		ptr.Elem().Set(value)
+		return SerializeFilter(ptr.Interface(), filter, opts...)
+	}
+	return value.Interface()
+}
+
+// applyMaskCallbacks runs the FieldSerializer callbacks carried by a
+// maskWithCallbacks against model, merging their output into result. It is a
+// no-op for any other FieldFilter implementation.
+func applyMaskCallbacks(model interface{}, filter FieldFilter, result map[string]interface{}) {
+	mwc, ok := filter.(maskWithCallbacks)
+	if !ok {
+		return
+	}
+	for _, callback := range mwc.callbacks {
+		fieldName, value := callback(model)
+		result[fieldName] = value
+	}
+}
@@ -0,0 +1,111 @@
+package model_serializer
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerializeFilterMask(t *testing.T) {
+	type nested struct {
+		FieldNested bool `json:"fieldNested"`
+	}
+	type testStruct struct {
+		Field1 string  `json:"field1"`
+		Field2 *nested `json:"field2"`
+	}
+
+	obj := testStruct{"foo", &nested{true}}
+
+	mask := Mask{
+		"Field1": nil,
+		"Field2": Mask{"FieldNested": nil},
+	}
+	res := SerializeFilter(&obj, mask)
+	expected := map[string]interface{}{
+		"field1": "foo",
+		"field2": map[string]interface{}{"fieldNested": true},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestSerializeFilterMaskInverse(t *testing.T) {
+	type testStruct struct {
+		Field1   string `json:"field1"`
+		Password string `json:"password"`
+	}
+
+	obj := testStruct{"foo", "secret"}
+
+	res := SerializeFilter(&obj, MaskInverse{"Password": struct{}{}})
+	assert.Equal(t, map[string]interface{}{"field1": "foo"}, res)
+}
+
+func TestSerializeFilterMaskInverseNested(t *testing.T) {
+	type owner struct {
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+	type testStruct struct {
+		ID    int    `json:"id"`
+		Owner *owner `json:"owner"`
+	}
+
+	obj := testStruct{1, &owner{"Alice", "s3cr3t"}}
+
+	res := SerializeFilter(&obj, MaskInverse{"Password": struct{}{}})
+	expected := map[string]interface{}{
+		"id":    1,
+		"owner": map[string]interface{}{"name": "Alice"},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestSerializeFilterMaskInverseSkipsUnexportedFields(t *testing.T) {
+	type realisticUser struct {
+		mu       sync.Mutex
+		Name     string `json:"name"`
+		Password string `json:"password"`
+	}
+
+	obj := realisticUser{Name: "Alice", Password: "s3cr3t"}
+
+	res := SerializeFilter(&obj, MaskInverse{"Password": struct{}{}})
+	assert.Equal(t, map[string]interface{}{"name": "Alice"}, res)
+}
+
+func TestParseMask(t *testing.T) {
+	mask, err := ParseMask("Field1,Field2{FieldNested}")
+	assert.NoError(t, err)
+	assert.Equal(t, Mask{
+		"Field1": nil,
+		"Field2": Mask{"FieldNested": nil},
+	}, mask)
+}
+
+func TestParseMaskMissingClosingBrace(t *testing.T) {
+	_, err := ParseMask("Field2{FieldNested")
+	assert.Error(t, err)
+}
+
+func TestSerializeWithParsedMask(t *testing.T) {
+	type nested struct {
+		FieldNested bool `json:"fieldNested"`
+	}
+	type testStruct struct {
+		Field1 string  `json:"field1"`
+		Field2 *nested `json:"field2"`
+	}
+
+	obj := testStruct{"foo", &nested{true}}
+
+	mask, err := ParseMask("Field1,Field2{FieldNested}")
+	assert.NoError(t, err)
+	res := SerializeFilter(&obj, mask)
+	expected := map[string]interface{}{
+		"field1": "foo",
+		"field2": map[string]interface{}{"fieldNested": true},
+	}
+	assert.Equal(t, expected, res)
+}
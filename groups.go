@@ -0,0 +1,117 @@
+package model_serializer
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// groupTag is the struct tag used to gate fields by serialization group,
+// e.g. `serializer:"public,admin"`.
+const groupTag = "serializer"
+
+// groupMaskCache caches the Mask built for a (reflect.Type, group) pair, so
+// repeated SerializeGroup/SerializeGroupList calls don't re-walk the type.
+var groupMaskCache sync.Map // map[groupMaskCacheKey]Mask
+
+type groupMaskCacheKey struct {
+	modelType reflect.Type
+	group     string
+}
+
+// SerializeGroup serializes model including every field tagged with group in
+// its `serializer` struct tag, e.g. `serializer:"public,admin"`. It recurses
+// into nested structs and slices/arrays of structs automatically, so callers
+// don't need to maintain a []interface{} fields list per endpoint; fields are
+// gated by role/group once, in the struct definition itself, instead.
+func SerializeGroup(model interface{}, group string, opts ...Option) map[string]interface{} {
+	val := reflect.ValueOf(model)
+	if val.IsNil() {
+		return make(map[string]interface{})
+	}
+	return SerializeFilter(model, groupMask(val.Elem().Type(), group), opts...)
+}
+
+// SerializeGroupList is the SerializeGroup counterpart of SerializeList.
+func SerializeGroupList(models interface{}, group string, opts ...Option) []map[string]interface{} {
+	slice := reflect.ValueOf(models)
+	res := make([]map[string]interface{}, slice.Len())
+
+	for i := 0; i < slice.Len(); i++ {
+		res[i] = SerializeGroup(slice.Index(i).Interface(), group, opts...)
+	}
+
+	return res
+}
+
+// groupMask returns the Mask selecting every field of modelType tagged with
+// group, building it on first use and caching the result.
+func groupMask(modelType reflect.Type, group string) Mask {
+	return buildGroupMask(modelType, group, map[reflect.Type]bool{})
+}
+
+// buildGroupMask builds (or returns the already-cached) Mask for modelType,
+// tracking the types currently being built in visiting so a self-referential
+// type (e.g. a tree/linked-list node pointing back at its own type) breaks
+// the cycle instead of recursing forever: once a type repeats, that branch
+// is left unfiltered (nil) rather than expanded again.
+func buildGroupMask(modelType reflect.Type, group string, visiting map[reflect.Type]bool) Mask {
+	key := groupMaskCacheKey{modelType, group}
+	if cached, ok := groupMaskCache.Load(key); ok {
+		return cached.(Mask)
+	}
+	if visiting[modelType] {
+		return nil
+	}
+	visiting[modelType] = true
+	defer delete(visiting, modelType)
+
+	mask := Mask{}
+	for i := 0; i < modelType.NumField(); i++ {
+		fieldType := modelType.Field(i)
+		if !hasGroup(fieldType, group) {
+			continue
+		}
+
+		if elemType := nestedElemType(fieldType.Type); elemType.Kind() == reflect.Struct {
+			// A nil Mask stored as-is would be a non-nil FieldFilter that
+			// selects nothing, so a cycle is stored as an untyped nil.
+			if sub := buildGroupMask(elemType, group, visiting); sub != nil {
+				mask[fieldType.Name] = sub
+			} else {
+				mask[fieldType.Name] = nil
+			}
+		} else {
+			mask[fieldType.Name] = nil
+		}
+	}
+
+	groupMaskCache.Store(key, mask)
+	return mask
+}
+
+// hasGroup reports whether field is tagged with group in its `serializer`
+// struct tag, e.g. `serializer:"public,admin"`.
+func hasGroup(field reflect.StructField, group string) bool {
+	tag, ok := field.Tag.Lookup(groupTag)
+	if !ok {
+		return false
+	}
+	for _, g := range strings.Split(tag, ",") {
+		if strings.TrimSpace(g) == group {
+			return true
+		}
+	}
+	return false
+}
+
+// nestedElemType unwraps pointer and slice/array container types down to the
+// underlying element type, e.g. []*Product -> Product.
+func nestedElemType(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return nestedElemType(t.Elem())
+	default:
+		return t
+	}
+}
@@ -0,0 +1,79 @@
+package model_serializer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerializeGroup(t *testing.T) {
+	type owner struct {
+		Name     string `json:"name" serializer:"public,admin"`
+		Password string `json:"password" serializer:"admin"`
+	}
+	type testStruct struct {
+		ID    int    `json:"id" serializer:"public,admin"`
+		Owner *owner `json:"owner" serializer:"public,admin"`
+	}
+
+	obj := testStruct{1, &owner{"Alice", "secret"}}
+
+	res := SerializeGroup(&obj, "public")
+	expected := map[string]interface{}{
+		"id":    1,
+		"owner": map[string]interface{}{"name": "Alice"},
+	}
+	assert.Equal(t, expected, res)
+
+	res = SerializeGroup(&obj, "admin")
+	expected = map[string]interface{}{
+		"id":    1,
+		"owner": map[string]interface{}{"name": "Alice", "password": "secret"},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestSerializeGroupUntaggedFieldsExcluded(t *testing.T) {
+	type testStruct struct {
+		ID     int    `json:"id" serializer:"public"`
+		Hidden string `json:"hidden"`
+	}
+
+	obj := testStruct{1, "nope"}
+	res := SerializeGroup(&obj, "public")
+	assert.Equal(t, map[string]interface{}{"id": 1}, res)
+}
+
+type groupTestNode struct {
+	Name     string           `json:"name" serializer:"public"`
+	Children []*groupTestNode `json:"children" serializer:"public"`
+}
+
+func TestSerializeGroupSelfReferentialType(t *testing.T) {
+	obj := groupTestNode{"root", []*groupTestNode{{Name: "child"}}}
+
+	assert.NotPanics(t, func() {
+		res := SerializeGroup(&obj, "public")
+		assert.Equal(t, "root", res["name"])
+	})
+}
+
+func TestSerializeGroupListOfStructs(t *testing.T) {
+	type product struct {
+		Name  string `json:"name" serializer:"public"`
+		Price int    `json:"price" serializer:"admin"`
+	}
+	type testStruct struct {
+		Products []*product `json:"products" serializer:"public"`
+	}
+
+	obj := testStruct{[]*product{{"foo", 10}, {"bar", 20}}}
+	res := SerializeGroup(&obj, "public")
+	expected := map[string]interface{}{
+		"products": []interface{}{
+			map[string]interface{}{"name": "foo"},
+			map[string]interface{}{"name": "bar"},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
@@ -0,0 +1,75 @@
+package model_serializer
+
+import (
+	"reflect"
+	"sync"
+)
+
+// Marshaler lets a type control its own serialized representation for kinds
+// Serialize can't otherwise express as json-tagged struct fields, such as
+// time.Time, decimal.Decimal, uuid.UUID or protobuf's anypb.Any. fields
+// carries whatever nested selection (if any) was requested for the field,
+// in the same format Serialize itself accepts.
+type Marshaler interface {
+	SerializeModel(fields []interface{}) interface{}
+}
+
+// TypeSerializerFunc customizes serialization for a type registered via
+// RegisterTypeSerializer. v is the field's reflect.Value; fields carries
+// whatever nested selection (if any) was requested for the field.
+type TypeSerializerFunc func(v reflect.Value, fields []interface{}) interface{}
+
+// typeSerializers holds third-party types registered via
+// RegisterTypeSerializer, keyed by reflect.Type.
+var typeSerializers sync.Map
+
+// RegisterTypeSerializer registers fn to serialize every field of type t.
+// It's meant for third-party types the caller doesn't own and so can't
+// implement Marshaler on directly (e.g. a vendored protobuf message); for a
+// type the caller owns, implementing Marshaler is usually simpler. When a
+// field's type implements Marshaler, that takes precedence over a
+// registered serializer for the same type.
+func RegisterTypeSerializer(t reflect.Type, fn TypeSerializerFunc) {
+	typeSerializers.Store(t, fn)
+}
+
+// serializeCustomField checks whether fieldValue should be serialized via
+// Marshaler or a registered TypeSerializerFunc instead of Serialize's
+// built-in reflect.Kind dispatch, returning the serialized value and true if
+// so.
+func serializeCustomField(fieldValue reflect.Value, filter FieldFilter) (interface{}, bool) {
+	if fieldValue.Kind() == reflect.Ptr && fieldValue.IsNil() {
+		return nil, false
+	}
+
+	if marshaler, ok := fieldValue.Interface().(Marshaler); ok {
+		return marshaler.SerializeModel(filterToFields(filter)), true
+	}
+
+	if fn, ok := typeSerializers.Load(fieldValue.Type()); ok {
+		return fn.(TypeSerializerFunc)(fieldValue, filterToFields(filter)), true
+	}
+
+	return nil, false
+}
+
+// filterToFields reconstructs a []interface{} selection (the format Serialize
+// accepts) equivalent to filter, for passing to Marshaler.SerializeModel and
+// TypeSerializerFunc, both of which predate FieldFilter. Only a Mask can be
+// represented this way; a nil filter (plain inclusion) or any other
+// FieldFilter implementation yields nil fields.
+func filterToFields(filter FieldFilter) []interface{} {
+	mask, ok := filter.(Mask)
+	if !ok {
+		return nil
+	}
+	fields := make([]interface{}, 0, len(mask))
+	for name, sub := range mask {
+		if sub == nil {
+			fields = append(fields, name)
+			continue
+		}
+		fields = append(fields, map[string][]interface{}{name: filterToFields(sub)})
+	}
+	return fields
+}
@@ -0,0 +1,54 @@
+package model_serializer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubTimestamp struct {
+	seconds int
+}
+
+func (s stubTimestamp) SerializeModel(fields []interface{}) interface{} {
+	return s.seconds
+}
+
+func TestSerializeMarshaler(t *testing.T) {
+	type testStruct struct {
+		CreatedAt stubTimestamp `json:"createdAt"`
+	}
+
+	obj := testStruct{stubTimestamp{42}}
+	res := Serialize(&obj, []interface{}{"CreatedAt"})
+	assert.Equal(t, map[string]interface{}{"createdAt": 42}, res)
+}
+
+type stubUUID struct {
+	value string
+}
+
+func TestRegisterTypeSerializer(t *testing.T) {
+	type testStruct struct {
+		ID stubUUID `json:"id"`
+	}
+
+	RegisterTypeSerializer(reflect.TypeOf(stubUUID{}), func(v reflect.Value, fields []interface{}) interface{} {
+		return v.Interface().(stubUUID).value
+	})
+
+	obj := testStruct{stubUUID{"abc-123"}}
+	res := Serialize(&obj, []interface{}{"ID"})
+	assert.Equal(t, map[string]interface{}{"id": "abc-123"}, res)
+}
+
+func TestSerializeFallbackToFieldName(t *testing.T) {
+	type testStruct struct {
+		Field1 string
+	}
+
+	obj := testStruct{"foo"}
+	res := Serialize(&obj, []interface{}{"Field1"}, WithFallbackToFieldName())
+	assert.Equal(t, map[string]interface{}{"Field1": "foo"}, res)
+}
@@ -1,7 +1,6 @@
 package model_serializer
 
 import (
-	"fmt"
 	"reflect"
 )
 
@@ -17,117 +16,130 @@ type FieldSerializer func(model interface{}) (fieldName string, value interface{
 // Parameters:
 // model  - pointer to struct should be serialized
 // fields - array of fields that should be included in result. Element can be a string or map[string][]interface{} for nested structs or array of structs
-//	the list may also include FieldSerializer functions
-func Serialize(model interface{}, fields []interface{}) map[string]interface{} {
-	result := make(map[string]interface{})
-
-	val := reflect.ValueOf(model)
-	if val.IsNil() {
-		return result
-	}
-
-	elem := val.Elem()
-	modelType := elem.Type()
-	// range all passed fields
-	for _, name := range fields {
-
-		switch reflect.ValueOf(name).Kind() {
-		case reflect.String:
-			serializeStrField(elem, modelType, name.(string), result)
-		case reflect.Func:
-			if fieldSerializer, ok := name.(FieldSerializer); ok {
-				fieldName, value := fieldSerializer(model)
-				result[fieldName] = value
-				continue
-			}
-			panic("Undefined func type for serializer.")
-		default:
-			// serialize nested structs or container of structs
-			for fieldNameStr, mapFields := range name.(map[string][]interface{}) {
-				fieldType, _ := modelType.FieldByName(fieldNameStr)
-				serializedName := getSerializedName(fieldType)
-				fieldValue := elem.FieldByName(fieldNameStr)
-				switch fieldType.Type.Kind() {
-				case reflect.Slice:
-					serializeArrayField(fieldValue, serializedName, mapFields, result)
-				case reflect.Array:
-					serializeArrayField(fieldValue, serializedName, mapFields, result)
-				case reflect.Ptr:
-					result[serializedName] = Serialize(fieldValue.Interface(), mapFields)
-				case reflect.Struct:
-					result[serializedName] = Serialize(fieldValue.Addr().Interface(), mapFields)
-				default:
-					panic("Undefined type for serializer. Need to implement it")
-				}
-			}
-		}
-	}
-	return result
+//	the list may also include FieldSerializer functions.
+//	A string can also be a dotted path, e.g. "Owner.Address.City" or "Products.*.Price" (the "*"
+//	marks a slice/array of structs), as a shortcut for hand-building the map[string][]interface{} form.
+//
+// Internally fields is compiled into a Mask and driven through SerializeFilter;
+// see FieldFilter to pass a compiled filter (e.g. from ParseMask) directly instead.
+func Serialize(model interface{}, fields []interface{}, opts ...Option) map[string]interface{} {
+	mask, callbacks := NewMask(fields)
+	return serializeWithMask(model, mask, callbacks, opts)
 }
 
-func SerializeList(models interface{}, fields []interface{}) []map[string]interface{} {
+// SerializeList compiles fields into a Mask once and reuses it for every
+// element, instead of re-deriving the same Mask per element the way calling
+// Serialize in a loop would.
+func SerializeList(models interface{}, fields []interface{}, opts ...Option) []map[string]interface{} {
+	mask, callbacks := NewMask(fields)
 	slice := reflect.ValueOf(models)
 	res := make([]map[string]interface{}, slice.Len())
 
 	for i := 0; i < slice.Len(); i++ {
-		res[i] = Serialize(slice.Index(i).Interface(), fields)
+		res[i] = serializeWithMask(slice.Index(i).Interface(), mask, callbacks, opts)
 	}
 
 	return res
 }
 
+// serializeWithMask is the shared tail of Serialize/SerializeList: run model
+// through an already-compiled Mask, then apply any FieldSerializer callbacks
+// carried alongside it.
+func serializeWithMask(model interface{}, mask Mask, callbacks []FieldSerializer, opts []Option) map[string]interface{} {
+	result := SerializeFilter(model, mask, opts...)
+
+	if val := reflect.ValueOf(model); !val.IsNil() {
+		for _, callback := range callbacks {
+			fieldName, value := callback(model)
+			result[fieldName] = value
+		}
+	}
+	return result
+}
+
 // FilterFields sets nil fot struct field if field is not in fields array.
-// Does not work for nested maps
+// A field can also be a dotted path, e.g. "Nested.CCC" or "Owner.Address.City",
+// which keeps the "Nested"/"Owner" field itself and recurses into it, zeroing
+// or deleting the nested field instead of the top-level one.
 func FilterFields(model interface{}, fields []string) {
 	modelValue := reflect.ValueOf(model).Elem()
 	modelType := modelValue.Type()
 	fieldsCount := modelValue.NumField()
 
+	topFields, nestedFields := splitNestedFilterFields(fields)
+
 	for i := 0; i < fieldsCount; i++ {
 		field := modelValue.Field(i)
-		if !field.IsNil() && !containsField(modelType.Field(i).Name, fields) {
+		fieldName := modelType.Field(i).Name
+		if subFields, ok := nestedFields[fieldName]; ok {
+			filterNestedField(field, subFields)
+			continue
+		}
+		if !field.IsNil() && !containsField(fieldName, topFields) {
 			field.Set(reflect.Zero(field.Type()))
 		}
 	}
 }
 
 // FilterMapFields removes fields not in array and nils.
-// Does not work for nested maps.
-// Can be used for updating only specified fields in model
+// Can be used for updating only specified fields in model.
+// A field can also be a dotted path, e.g. "Nested.CCC", which keeps the
+// "Nested" key itself and recurses into its value, deleting the nested field
+// instead of the top-level one.
 func FilterMapFields(mapData map[string]interface{}, fields []string) {
+	topFields, nestedFields := splitNestedFilterFields(fields)
+
 	for k, v := range mapData {
-		if !containsField(k, fields) || isNilInterface(v) {
+		if subFields, ok := nestedFields[k]; ok {
+			filterNestedMapValue(v, subFields)
+			continue
+		}
+		if !containsField(k, topFields) || isNilInterface(v) {
 			delete(mapData, k)
 		}
 	}
 }
 
-func getSerializedName(structField reflect.StructField) string {
-	if val, ok := structField.Tag.Lookup("json"); !ok {
-		panic(fmt.Sprintf(`Field "%s" has no json tag`, structField.Name))
-	} else {
-		return val
+// filterNestedField recurses FilterFields/FilterMapFields into a struct
+// field addressed by a dotted path, descending through pointers to structs,
+// struct values, map[string]interface{} maps and slices/arrays of those;
+// fields of any other kind, including other map types, are left unchanged.
+func filterNestedField(field reflect.Value, subFields []string) {
+	switch field.Kind() {
+	case reflect.Ptr:
+		if field.IsNil() || field.Elem().Kind() != reflect.Struct {
+			return
+		}
+		FilterFields(field.Interface(), subFields)
+	case reflect.Struct:
+		FilterFields(field.Addr().Interface(), subFields)
+	case reflect.Map:
+		if mapData, ok := field.Interface().(map[string]interface{}); ok {
+			FilterMapFields(mapData, subFields)
+		}
+	case reflect.Slice, reflect.Array:
+		elemFields := stripWildcardFields(subFields)
+		for i := 0; i < field.Len(); i++ {
+			filterNestedField(field.Index(i), elemFields)
+		}
 	}
 }
 
-func serializeArrayField(fieldValue reflect.Value, fieldName string,
-	fields []interface{}, targetMap map[string]interface{},
-) {
-	serializedContainer := make([]interface{}, fieldValue.Len())
-	for i := 0; i < fieldValue.Len(); i++ {
-		elemInterface := fieldValue.Index(i).Interface()
-		serializedContainer[i] = Serialize(elemInterface, fields)
+// filterNestedMapValue is the map-value counterpart of filterNestedField,
+// used when recursing FilterMapFields into a value held behind interface{}.
+func filterNestedMapValue(v interface{}, subFields []string) {
+	if isNilInterface(v) {
+		return
+	}
+	switch typed := v.(type) {
+	case map[string]interface{}:
+		FilterMapFields(typed, subFields)
+	default:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.Elem().Kind() == reflect.Struct {
+			FilterFields(v, subFields)
+		}
 	}
-	targetMap[fieldName] = serializedContainer
-}
-
-func serializeStrField(structValue reflect.Value, structType reflect.Type,
-	fieldName string, targetMap map[string]interface{},
-) {
-	fieldValue := structValue.FieldByName(fieldName)
-	fieldType, _ := structType.FieldByName(fieldName)
-	serializedName := getSerializedName(fieldType)
-	targetMap[serializedName] = fieldValue.Interface()
 }
 
 func isNilInterface(v interface{}) bool {
@@ -144,7 +144,7 @@ func TestSerializeFieldWithArrayOfStructs(t *testing.T) {
 
 func TestSerializeFieldWithUnexpectedPassedField(t *testing.T) {
 	type testStruct struct {
-		Field1 map[string]string `json:"field"`
+		Field1 chan int `json:"field"`
 	}
 
 	obj := testStruct{}
@@ -154,6 +154,39 @@ func TestSerializeFieldWithUnexpectedPassedField(t *testing.T) {
 	})
 }
 
+func TestSerializeFieldWithMap(t *testing.T) {
+	type testStruct struct {
+		Field1 map[string]string `json:"field"`
+	}
+
+	obj := testStruct{map[string]string{"a": "foo", "b": "bar"}}
+	fields := []interface{}{"Field1"}
+	res := Serialize(&obj, fields)
+	assert.Equal(t, map[string]interface{}{"field": map[string]string{"a": "foo", "b": "bar"}}, res)
+}
+
+func TestSerializeFieldWithMapOfStructs(t *testing.T) {
+	type nested struct {
+		FieldNested bool `json:"fieldNested"`
+	}
+	type testStruct struct {
+		Field1 map[string]*nested `json:"field"`
+	}
+
+	obj := testStruct{map[string]*nested{"a": {true}, "b": {false}}}
+	fields := []interface{}{
+		map[string][]interface{}{"Field1": {"FieldNested"}},
+	}
+	res := Serialize(&obj, fields)
+	expected := map[string]interface{}{
+		"field": map[string]interface{}{
+			"a": map[string]interface{}{"fieldNested": true},
+			"b": map[string]interface{}{"fieldNested": false},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
+
 func TestFilterFields(t *testing.T) {
 	type testStruct struct {
 		Field1 *string `json:"field1"`
@@ -176,3 +209,106 @@ func TestFilterMapFields(t *testing.T) {
 	expected := map[string]interface{}{"field1": 1}
 	assert.Equal(t, expected, obj)
 }
+
+func TestSerializeDottedPath(t *testing.T) {
+	type address struct {
+		City string `json:"city"`
+	}
+	type owner struct {
+		Name    string  `json:"name"`
+		Address address `json:"address"`
+	}
+	type testStruct struct {
+		Owner owner `json:"owner"`
+	}
+
+	obj := testStruct{owner{"Alice", address{"Berlin"}}}
+
+	fields := []interface{}{"Owner.Address.City", "Owner.Name"}
+	res := Serialize(&obj, fields)
+	expected := map[string]interface{}{
+		"owner": map[string]interface{}{
+			"name":    "Alice",
+			"address": map[string]interface{}{"city": "Berlin"},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestSerializeDottedPathWithWildcard(t *testing.T) {
+	type product struct {
+		Name  string `json:"name"`
+		Price int    `json:"price"`
+	}
+	type testStruct struct {
+		Products []*product `json:"products"`
+	}
+
+	obj := testStruct{[]*product{{"foo", 10}, {"bar", 20}}}
+
+	fields := []interface{}{"Products.*.Price"}
+	res := Serialize(&obj, fields)
+	expected := map[string]interface{}{
+		"products": []interface{}{
+			map[string]interface{}{"price": 10},
+			map[string]interface{}{"price": 20},
+		},
+	}
+	assert.Equal(t, expected, res)
+}
+
+func TestFilterFieldsNestedStruct(t *testing.T) {
+	type nested struct {
+		AAA *string
+		CCC *string
+	}
+	type testStruct struct {
+		Nested *nested
+	}
+
+	aaa, ccc := "foo", "bar"
+	obj := testStruct{&nested{&aaa, &ccc}}
+	FilterFields(&obj, []string{"Nested.AAA"})
+	assert.Equal(t, "foo", *obj.Nested.AAA)
+	assert.Nil(t, obj.Nested.CCC)
+}
+
+func TestFilterFieldsNestedMap(t *testing.T) {
+	type testStruct struct {
+		Extra map[string]interface{}
+	}
+
+	obj := testStruct{map[string]interface{}{"secret": "x", "public": "y"}}
+	FilterFields(&obj, []string{"Extra.public"})
+	assert.Equal(t, map[string]interface{}{"public": "y"}, obj.Extra)
+}
+
+func TestFilterFieldsNestedSliceWildcard(t *testing.T) {
+	type product struct {
+		Name  *string
+		Price *string
+	}
+	type testStruct struct {
+		Products []*product
+	}
+
+	name1, price1 := "foo", "10"
+	name2, price2 := "bar", "20"
+	obj := testStruct{[]*product{{&name1, &price1}, {&name2, &price2}}}
+	FilterFields(&obj, []string{"Products.*.Price"})
+	assert.Equal(t, "10", *obj.Products[0].Price)
+	assert.Equal(t, "20", *obj.Products[1].Price)
+	assert.Nil(t, obj.Products[0].Name)
+	assert.Nil(t, obj.Products[1].Name)
+}
+
+func TestFilterMapFieldsNestedMap(t *testing.T) {
+	obj := map[string]interface{}{
+		"owner": map[string]interface{}{"name": "Alice", "secret": "x"},
+	}
+	FilterMapFields(obj, []string{"owner.name"})
+	expected := map[string]interface{}{
+		"owner": map[string]interface{}{"name": "Alice"},
+	}
+	assert.Equal(t, expected, obj)
+}
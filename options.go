@@ -0,0 +1,26 @@
+package model_serializer
+
+// SerializeOptions controls optional behavior of Serialize, SerializeFilter
+// and SerializeGroup.
+type SerializeOptions struct {
+	// FallbackToFieldName makes a field with no "json" tag serialize under
+	// its Go field name instead of panicking.
+	FallbackToFieldName bool
+}
+
+// Option configures SerializeOptions.
+type Option func(*SerializeOptions)
+
+// WithFallbackToFieldName makes a field with no "json" tag serialize under
+// its Go field name instead of panicking.
+func WithFallbackToFieldName() Option {
+	return func(o *SerializeOptions) { o.FallbackToFieldName = true }
+}
+
+func buildOptions(opts []Option) SerializeOptions {
+	var options SerializeOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
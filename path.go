@@ -0,0 +1,105 @@
+package model_serializer
+
+import "strings"
+
+// splitDottedPath splits a path like "Owner.Address.City" on its first "."
+// returning the head segment and the remaining path, e.g. "Owner" and
+// "Address.City". ok is false when path has no nested part.
+func splitDottedPath(path string) (head, rest string, ok bool) {
+	idx := strings.Index(path, ".")
+	if idx < 0 {
+		return path, "", false
+	}
+	return path[:idx], path[idx+1:], true
+}
+
+// compileFields expands dotted-path strings such as "Owner.Address.City" or
+// "Products.*.Price" into the nested map[string][]interface{} form Serialize
+// already understands, merging them with any selection already present for
+// the same field. Plain strings, map[string][]interface{} entries and
+// FieldSerializer values pass through unchanged.
+func compileFields(fields []interface{}) []interface{} {
+	compiled := make([]interface{}, 0, len(fields))
+	for _, f := range fields {
+		name, ok := f.(string)
+		if !ok || !strings.Contains(name, ".") {
+			compiled = append(compiled, f)
+			continue
+		}
+		compiled = compilePathField(compiled, name)
+	}
+	return compiled
+}
+
+// compilePathField folds a single dotted path into fields, merging it with
+// an existing nested selection for the same head field if one is already
+// present. A "*" segment (e.g. "Products.*.Price") marks a slice or array of
+// structs and is transparent: Serialize already applies the nested fields to
+// every element, so it is simply skipped while compiling.
+func compilePathField(fields []interface{}, path string) []interface{} {
+	head, rest, ok := splitDottedPath(path)
+	if !ok {
+		return appendFieldName(fields, path)
+	}
+	if head == "*" {
+		return compilePathField(fields, rest)
+	}
+	for _, f := range fields {
+		nested, isMap := f.(map[string][]interface{})
+		if !isMap {
+			continue
+		}
+		if sub, exists := nested[head]; exists {
+			nested[head] = compilePathField(sub, rest)
+			return fields
+		}
+	}
+	return append(fields, map[string][]interface{}{head: compilePathField(nil, rest)})
+}
+
+func appendFieldName(fields []interface{}, name string) []interface{} {
+	for _, f := range fields {
+		if s, isStr := f.(string); isStr && s == name {
+			return fields
+		}
+	}
+	return append(fields, name)
+}
+
+// stripWildcardFields strips a leading "*." segment from each path, used
+// when recursing FilterFields/FilterMapFields into a slice/array of structs
+// (e.g. "Products.*.Price" becomes "Price" for each element). This mirrors
+// how compilePathField treats "*" as transparent for Serialize: the wildcard
+// already iterates every element, so it carries no field-name information of
+// its own. An entry that is just "*" (no rest) selects no individual field
+// and is dropped; any entry without a "*" head passes through unchanged.
+func stripWildcardFields(fields []string) []string {
+	stripped := make([]string, 0, len(fields))
+	for _, f := range fields {
+		head, rest, ok := splitDottedPath(f)
+		if !ok || head != "*" {
+			stripped = append(stripped, f)
+			continue
+		}
+		if rest != "" {
+			stripped = append(stripped, rest)
+		}
+	}
+	return stripped
+}
+
+// splitNestedFilterFields splits a flat fields list into names that apply
+// directly at this level ("Field2") and dotted paths that recurse into a
+// nested field or map ("Nested.CCC" groups under "Nested" as ["CCC"]).
+func splitNestedFilterFields(fields []string) (top []string, nested map[string][]string) {
+	nested = make(map[string][]string)
+	for _, f := range fields {
+		head, rest, ok := splitDottedPath(f)
+		if !ok {
+			top = append(top, f)
+			continue
+		}
+		nested[head] = append(nested[head], rest)
+	}
+	return top, nested
+}
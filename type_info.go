@@ -0,0 +1,95 @@
+package model_serializer
+
+import (
+	"reflect"
+	"sync"
+)
+
+// fieldKind is a precomputed dispatch enum for a struct field, so
+// SerializeFilter doesn't have to re-inspect reflect.Type.Kind() on every
+// call. It is derived from the field's own kind only, not from a container's
+// element type.
+type fieldKind int
+
+const (
+	kindString fieldKind = iota
+	kindNestedStruct
+	kindSlice
+	kindPtr
+	kindMap
+	kindUnsupported
+)
+
+// fieldInfo is the cached, per-field part of a struct's typeInfo: the index
+// to reach it with Value.Field(i), its pre-extracted json tag name (empty if
+// absent) and its dispatch kind.
+type fieldInfo struct {
+	index          int
+	name           string
+	serializedName string
+	kind           fieldKind
+}
+
+// typeInfo is a struct type's fields, described once via reflect and then
+// reused by every Serialize/SerializeFilter/SerializeGroup call against that
+// type, the same way encoding/json caches its own typeFields per type.
+type typeInfo struct {
+	fields []fieldInfo
+}
+
+var typeInfoCache sync.Map // map[reflect.Type]*typeInfo
+
+// getTypeInfo returns the cached typeInfo for t, building and storing it on
+// first use.
+func getTypeInfo(t reflect.Type) *typeInfo {
+	if cached, ok := typeInfoCache.Load(t); ok {
+		return cached.(*typeInfo)
+	}
+
+	info := buildTypeInfo(t)
+	actual, _ := typeInfoCache.LoadOrStore(t, info)
+	return actual.(*typeInfo)
+}
+
+// buildTypeInfo walks t's fields, skipping unexported ones (PkgPath != "")
+// exactly as encoding/json's typeFields does, since they can't be addressed
+// via reflect.Value.Interface() and have no business being serialized or
+// counted against an all-fields walk like MaskInverse/SerializeGroup.
+func buildTypeInfo(t reflect.Type) *typeInfo {
+	fields := make([]fieldInfo, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		serializedName, _ := field.Tag.Lookup("json")
+		fields = append(fields, fieldInfo{
+			index:          i,
+			name:           field.Name,
+			serializedName: serializedName,
+			kind:           dispatchKind(field.Type),
+		})
+	}
+	return &typeInfo{fields: fields}
+}
+
+func dispatchKind(t reflect.Type) fieldKind {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return kindSlice
+	case reflect.Ptr:
+		return kindPtr
+	case reflect.Struct:
+		return kindNestedStruct
+	case reflect.Map:
+		return kindMap
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Complex64, reflect.Complex128:
+		return kindString
+	default:
+		return kindUnsupported
+	}
+}
@@ -0,0 +1,47 @@
+package model_serializer
+
+import "testing"
+
+type benchProduct struct {
+	ID    int     `json:"id"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+}
+
+func BenchmarkSerializeListFlatFields(b *testing.B) {
+	products := make([]*benchProduct, 1000)
+	for i := range products {
+		products[i] = &benchProduct{ID: i, Name: "product", Price: 9.99}
+	}
+	fields := []interface{}{"ID", "Name", "Price"}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SerializeList(products, fields)
+	}
+}
+
+type benchOwner struct {
+	Name string `json:"name"`
+}
+
+type benchModel struct {
+	ID    int         `json:"id"`
+	Owner *benchOwner `json:"owner"`
+}
+
+func BenchmarkSerializeListNestedStruct(b *testing.B) {
+	models := make([]*benchModel, 1000)
+	for i := range models {
+		models[i] = &benchModel{ID: i, Owner: &benchOwner{Name: "owner"}}
+	}
+	fields := []interface{}{
+		"ID",
+		map[string][]interface{}{"Owner": {"Name"}},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		SerializeList(models, fields)
+	}
+}